@@ -0,0 +1,173 @@
+// Package daemon rotates wallpapers on an interval and reacts to control
+// signals, persisting enough state to resume cleanly across restarts.
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+)
+
+// RotateFunc fetches, downloads and sets the next wallpaper, returning the
+// absolute path it was saved to.
+type RotateFunc func(ctx context.Context) (wallpaperPath string, err error)
+
+// Config configures a Daemon.
+type Config struct {
+	Interval     time.Duration
+	FavoritesDir string
+	Rotate       RotateFunc
+	// Log receives a human-readable line for every daemon event, if set.
+	Log func(string)
+}
+
+// Daemon rotates wallpapers on Config.Interval and, on platforms with POSIX
+// signals, handles SIGUSR1 (rotate now), SIGUSR2 (favorite the current
+// wallpaper) and SIGHUP (reload state from disk). See signals_unix.go and
+// signals_windows.go.
+type Daemon struct {
+	cfg   Config
+	state *State
+}
+
+// New creates a Daemon, loading any previously persisted state.
+func New(cfg Config) (*Daemon, error) {
+	state, err := LoadState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daemon state: %w", err)
+	}
+	return &Daemon{cfg: cfg, state: state}, nil
+}
+
+// Run rotates wallpapers until ctx is done, blocking the caller.
+func (d *Daemon) Run(ctx context.Context) error {
+	sigCh := make(chan os.Signal, 1)
+	if len(controlSignals) > 0 {
+		signal.Notify(sigCh, controlSignals...)
+		defer signal.Stop(sigCh)
+	}
+
+	if d.state.NextRotate.IsZero() || time.Now().After(d.state.NextRotate) {
+		if err := d.rotate(ctx); err != nil {
+			d.logf("rotate failed: %v", err)
+		}
+	}
+
+	timer := time.NewTimer(time.Until(d.state.NextRotate))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case sig := <-sigCh:
+			switch sig {
+			case sigRotate:
+				d.logf("received %s, rotating now", sig)
+				if err := d.rotate(ctx); err != nil {
+					d.logf("rotate failed: %v", err)
+				}
+				resetTimer(timer, d.cfg.Interval)
+
+			case sigFavorite:
+				d.logf("received %s, favoriting current wallpaper", sig)
+				if err := d.favoriteCurrent(); err != nil {
+					d.logf("favorite failed: %v", err)
+				}
+
+			case sigReload:
+				d.logf("received %s, reloading state", sig)
+				if s, err := LoadState(); err != nil {
+					d.logf("reload failed: %v", err)
+				} else {
+					d.state = s
+					resetTimer(timer, time.Until(d.state.NextRotate))
+				}
+			}
+
+		case <-timer.C:
+			if err := d.rotate(ctx); err != nil {
+				d.logf("rotate failed: %v", err)
+			}
+			resetTimer(timer, d.cfg.Interval)
+		}
+	}
+}
+
+// rotate fetches the next wallpaper, records it in state and persists the
+// new next-rotate time.
+func (d *Daemon) rotate(ctx context.Context) error {
+	path, err := d.cfg.Rotate(ctx)
+	if err != nil {
+		return err
+	}
+
+	d.state.push(path)
+	d.state.NextRotate = time.Now().Add(d.cfg.Interval)
+	if err := d.state.Save(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	d.logf("rotated to %s, next rotate at %s", path, d.state.NextRotate.Format(time.RFC3339))
+	return nil
+}
+
+// favoriteCurrent hardlinks the current wallpaper into FavoritesDir, copying
+// it instead if the link can't be made.
+func (d *Daemon) favoriteCurrent() error {
+	if d.state.Current == "" {
+		return fmt.Errorf("no current wallpaper to favorite")
+	}
+	if err := os.MkdirAll(d.cfg.FavoritesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create favorites directory: %w", err)
+	}
+
+	dest := filepath.Join(d.cfg.FavoritesDir, filepath.Base(d.state.Current))
+	if err := os.Link(d.state.Current, dest); err == nil {
+		return nil
+	}
+	return copyFile(d.state.Current, dest)
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+func (d *Daemon) logf(format string, args ...any) {
+	if d.cfg.Log != nil {
+		d.cfg.Log(fmt.Sprintf(format, args...))
+	}
+}
+
+// resetTimer drains and resets t to fire after d, tolerating an already-fired
+// or already-drained timer.
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	if d < 0 {
+		d = 0
+	}
+	t.Reset(d)
+}
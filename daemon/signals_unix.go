@@ -0,0 +1,20 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"os"
+	"syscall"
+)
+
+// sigRotate, sigFavorite and sigReload are the control signals Run reacts
+// to: rotate now, favorite the current wallpaper, and reload state from
+// disk, respectively. See signals_windows.go for the no-op Windows variant.
+var (
+	sigRotate   os.Signal = syscall.SIGUSR1
+	sigFavorite os.Signal = syscall.SIGUSR2
+	sigReload   os.Signal = syscall.SIGHUP
+)
+
+// controlSignals are the signals Run registers with signal.Notify.
+var controlSignals = []os.Signal{sigRotate, sigFavorite, sigReload}
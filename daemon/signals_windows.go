@@ -0,0 +1,17 @@
+//go:build windows
+
+package daemon
+
+import "os"
+
+// Windows has no POSIX signals, so sigRotate, sigFavorite and sigReload are
+// left nil and never delivered: the daemon only rotates on its timer. See
+// signals_unix.go for the real signal set.
+var (
+	sigRotate   os.Signal
+	sigFavorite os.Signal
+	sigReload   os.Signal
+)
+
+// controlSignals is empty on Windows, so Run skips signal.Notify entirely.
+var controlSignals []os.Signal
@@ -0,0 +1,86 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// historyLimit bounds the ring buffer of previously set wallpapers kept in
+// State.History.
+const historyLimit = 50
+
+// State is gohaven's daemon state, persisted to disk so rotation can resume
+// across restarts.
+type State struct {
+	Current    string    `json:"current_wallpaper"`
+	History    []string  `json:"history"`
+	NextRotate time.Time `json:"next_rotate"`
+}
+
+// push records path as the current wallpaper and appends it to History,
+// trimming the oldest entries once historyLimit is exceeded.
+func (s *State) push(path string) {
+	s.Current = path
+	s.History = append(s.History, path)
+	if len(s.History) > historyLimit {
+		s.History = s.History[len(s.History)-historyLimit:]
+	}
+}
+
+// statePath returns $XDG_STATE_HOME/gohaven/state.json, falling back to
+// ~/.local/state/gohaven/state.json per the XDG base directory spec.
+func statePath() (string, error) {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateHome, "gohaven", "state.json"), nil
+}
+
+// LoadState reads gohaven's persisted state, returning a zero State if none
+// has been saved yet.
+func LoadState() (*State, error) {
+	path, err := statePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Save persists s to the gohaven state file, creating its directory if
+// needed.
+func (s *State) Save() error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
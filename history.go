@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bk0nlyrica/gohaven/providers"
+	"github.com/bk0nlyrica/gohaven/wmset"
+)
+
+// newHistoryCmd builds the "history" subcommand and its list/reset/replay
+// children, which all operate on the wallpaper index at picturesDir rather
+// than fetching anything new.
+func newHistoryCmd(picturesDir, mode, scriptPath *string) *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "List, reset or replay previously set wallpapers",
+	}
+
+	limit := 0
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List previously set wallpapers, most recent first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openStore(*picturesDir)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			records, err := store.History(limit)
+			if err != nil {
+				return err
+			}
+			for _, r := range records {
+				favorite := ""
+				if r.Favorite {
+					favorite = " [favorite]"
+				}
+				fmt.Printf("%s  %-10s %-20s %s%s\n", r.LastSetAt.Format(time.RFC3339), r.Source, r.ID, r.Path, favorite)
+			}
+			return nil
+		},
+	}
+	listCmd.Flags().IntVar(&limit, "limit", 50, "maximum number of entries to show")
+
+	resetCmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Clear the wallpaper index (downloaded files are kept)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openStore(*picturesDir)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			return store.Reset()
+		},
+	}
+
+	replayCmd := &cobra.Command{
+		Use:   "replay <source> <id>",
+		Short: "Re-set a previously downloaded wallpaper from the index",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, id := args[0], args[1]
+
+			store, err := openStore(*picturesDir)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			record, err := store.Lookup(source, id)
+			if err != nil {
+				return err
+			}
+			if record == nil {
+				return fmt.Errorf("no wallpaper indexed for %s/%s", source, id)
+			}
+
+			meta := providers.WallpaperMeta{
+				ID:         record.ID,
+				SourceURL:  record.SourceURL,
+				Resolution: record.Resolution,
+				Tags:       record.Tags,
+				Colors:     record.Colors,
+			}
+
+			if err := store.MarkSet(source, id, time.Now()); err != nil {
+				return err
+			}
+
+			_, err = setAndHook(record.Path, *picturesDir, meta, wmset.Mode(*mode), *scriptPath)
+			return err
+		},
+	}
+
+	historyCmd.AddCommand(listCmd, resetCmd, replayCmd)
+	return historyCmd
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bk0nlyrica/gohaven/providers"
+)
+
+// runScript invokes scriptPath with absPath as its first argument, passing
+// along metadata about the wallpaper as environment variables so the script
+// can drive things like pywal or a lockscreen update without gohaven knowing
+// about them. Output is captured into logBuffer; a non-zero exit is returned
+// as an error. scriptPath is a no-op if empty.
+func runScript(scriptPath string, absPath string, meta providers.WallpaperMeta) error {
+	if scriptPath == "" {
+		return nil
+	}
+
+	cmd := exec.Command(scriptPath, absPath)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("GOHAVEN_ID=%s", meta.ID),
+		fmt.Sprintf("GOHAVEN_SOURCE_URL=%s", meta.SourceURL),
+		fmt.Sprintf("GOHAVEN_RESOLUTION=%s", meta.Resolution),
+		fmt.Sprintf("GOHAVEN_COLORS=%s", strings.Join(meta.Colors, ",")),
+	)
+
+	stdout, stderr := new(strings.Builder), new(strings.Builder)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	logBuffer.WriteString(fmt.Sprintf("running script: %s %s\n", scriptPath, absPath))
+	runErr := cmd.Run()
+
+	if stdout.Len() > 0 {
+		logBuffer.WriteString(fmt.Sprintf("script stdout: %s\n", stdout.String()))
+	}
+	if stderr.Len() > 0 {
+		logBuffer.WriteString(fmt.Sprintf("script stderr: %s\n", stderr.String()))
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("script %s failed: %v", scriptPath, runErr)
+	}
+	return nil
+}
@@ -1,18 +1,21 @@
 package main
 
 import (
-	"bufio"
+	"context"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/PuerkitoBio/goquery"
+	"github.com/spf13/cobra"
+
+	"github.com/bk0nlyrica/gohaven/daemon"
+	providerwallhaven "github.com/bk0nlyrica/gohaven/providers/wallhaven"
+	"github.com/bk0nlyrica/gohaven/storage"
+	"github.com/bk0nlyrica/gohaven/wallhaven"
+	"github.com/bk0nlyrica/gohaven/wmset"
 )
 
 const (
@@ -21,6 +24,45 @@ const (
 
 var logBuffer strings.Builder
 
+// filterFlags holds the Wallhaven search filters shared by the random,
+// search, top and daemon subcommands.
+type filterFlags struct {
+	categories string
+	purity     string
+	sorting    string
+	order      string
+	ratios     string
+	atLeast    string
+	page       int
+}
+
+func (f filterFlags) toParams(query string) wallhaven.SearchParams {
+	return wallhaven.SearchParams{
+		Query:      query,
+		Categories: f.categories,
+		Purity:     f.purity,
+		Sorting:    f.sorting,
+		Order:      f.order,
+		Ratios:     f.ratios,
+		AtLeast:    f.atLeast,
+		Page:       f.page,
+	}
+}
+
+// addFilterFlags registers the shared Wallhaven search filters on cmd and
+// returns the struct they're bound to.
+func addFilterFlags(cmd *cobra.Command) *filterFlags {
+	f := &filterFlags{}
+	cmd.Flags().StringVar(&f.categories, "categories", "111", "category bitmask general/anime/people, e.g. 111")
+	cmd.Flags().StringVar(&f.purity, "purity", "100", "purity bitmask sfw/sketchy/nsfw, e.g. 100 (nsfw requires --apikey)")
+	cmd.Flags().StringVar(&f.sorting, "sorting", "date_added", "date_added, relevance, random, views, favorites, toplist")
+	cmd.Flags().StringVar(&f.order, "order", "desc", "desc or asc")
+	cmd.Flags().StringVar(&f.ratios, "ratios", "", "comma separated aspect ratios, e.g. 16x9,16x10")
+	cmd.Flags().StringVar(&f.atLeast, "atleast", "", "minimum resolution, e.g. 2560x1440")
+	cmd.Flags().IntVar(&f.page, "page", 1, "page of results to fetch (search and top only; random picking reads pages from 1)")
+	return f
+}
+
 func writeLog() error {
 	now := time.Now()
 	logFileName := fmt.Sprintf("%d-%d-%d.log", now.Day(), now.Month(), now.Year())
@@ -42,40 +84,11 @@ func writeLog() error {
 	return nil
 }
 
-// detectWindowManager returns the detected window manager ("gnome", "dwm", "i3", or "unknown").
-func detectWindowManager() string {
-	// Check environment variables
-	xdgDesktop := os.Getenv("XDG_CURRENT_DESKTOP")
-	desktopSession := os.Getenv("DESKTOP_SESSION")
-	if strings.Contains(strings.ToLower(xdgDesktop), "gnome") || strings.Contains(strings.ToLower(desktopSession), "gnome") {
-		return "gnome"
-	}
-	if strings.Contains(strings.ToLower(xdgDesktop), "i3") || strings.Contains(strings.ToLower(desktopSession), "i3") {
-		return "i3"
-	}
-	if strings.Contains(strings.ToLower(xdgDesktop), "dwm") || strings.Contains(strings.ToLower(desktopSession), "dwm") {
-		return "dwm"
-	}
-
-	// Fallback: Check running processes
-	cmd := exec.Command("pgrep", "-l", "dwm")
-	output, err := cmd.Output()
-	if err == nil && strings.Contains(string(output), "dwm") {
-		return "dwm"
-	}
-
-	cmd = exec.Command("pgrep", "-l", "i3")
-	output, err = cmd.Output()
-	if err == nil && strings.Contains(string(output), "i3") {
-		return "i3"
-	}
-
-	return "unknown"
-}
-
-func setWallpaper(wallpaperFileName string, picturesDir string) error {
+// setWallpaper detects the running window manager or desktop environment
+// and asks its wmset.Setter to apply wallpaperFileName in picturesDir using
+// the given mode.
+func setWallpaper(wallpaperFileName string, picturesDir string, mode wmset.Mode) error {
 	wallpaperPath := filepath.Join(picturesDir, wallpaperFileName)
-	// Get absolute path
 	absPath, err := filepath.Abs(wallpaperPath)
 	if err != nil {
 		logBuffer.WriteString(fmt.Sprintf("Error getting absolute path: %v\n", err))
@@ -85,108 +98,23 @@ func setWallpaper(wallpaperFileName string, picturesDir string) error {
 		return fmt.Errorf("failed to get absolute path: %v", err)
 	}
 
-	wm := detectWindowManager()
-	logBuffer.WriteString(fmt.Sprintf("Detected window manager: %s\n", wm))
-
-	switch wm {
-	case "gnome":
-		// Set wallpaper using gsettings
-		command := fmt.Sprintf("gsettings set org.gnome.desktop.background picture-uri file://%s", absPath)
-		fmt.Println("running:", command)
-		logBuffer.WriteString(fmt.Sprintf("%s\n", command))
-
-		cmd := exec.Command("gsettings", "set", "org.gnome.desktop.background", "picture-uri", fmt.Sprintf("file://%s", absPath))
-		stdout, stderr := new(strings.Builder), new(strings.Builder)
-		cmd.Stdout = stdout
-		cmd.Stderr = stderr
-
-		if err := cmd.Run(); err != nil {
-			logBuffer.WriteString(fmt.Sprintf("Error: %v\n", err))
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
-			return fmt.Errorf("failed to set wallpaper: %v", err)
-		}
-
-		if stderr.String() != "" {
-			logBuffer.WriteString(fmt.Sprintf("stderr: %s\n", stderr.String()))
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
-			return fmt.Errorf("gsettings stderr: %s", stderr.String())
-		}
-
-		fmt.Println(stdout.String())
-		logBuffer.WriteString(fmt.Sprintf("stdout: %s\n", stdout.String()))
-	case "dwm", "i3":
-		// Check if feh is installed
-		if _, err := exec.LookPath("feh"); err != nil {
-			logBuffer.WriteString("Error: feh not found. Please install feh to set wallpapers in DWM or i3.\n")
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
-			return fmt.Errorf("feh not found: %v", err)
-		}
-
-		// Set wallpaper using feh
-		command := fmt.Sprintf("feh --bg-scale %s", absPath)
-		fmt.Println("running:", command)
-		logBuffer.WriteString(fmt.Sprintf("%s\n", command))
-
-		cmd := exec.Command("feh", "--bg-scale", absPath)
-		stdout, stderr := new(strings.Builder), new(strings.Builder)
-		cmd.Stdout = stdout
-		cmd.Stderr = stderr
-
-		if err := cmd.Run(); err != nil {
-			logBuffer.WriteString(fmt.Sprintf("Error: %v\n", err))
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
-			return fmt.Errorf("failed to set wallpaper with feh: %v", err)
+	setter := wmset.Detect()
+	if setter == nil {
+		logBuffer.WriteString("Error: no supported window manager or desktop environment detected.\n")
+		if err := writeLog(); err != nil {
+			log.Printf("Failed to write log: %v", err)
 		}
+		return fmt.Errorf("no supported window manager or desktop environment detected")
+	}
 
-		if stderr.String() != "" {
-			logBuffer.WriteString(fmt.Sprintf("stderr: %s\n", stderr.String()))
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
-			return fmt.Errorf("feh stderr: %s", stderr.String())
-		}
+	logBuffer.WriteString(fmt.Sprintf("Detected window manager: %s\n", setter.Name()))
 
-		fmt.Println(stdout.String())
-		logBuffer.WriteString(fmt.Sprintf("stdout: %s\n", stdout.String()))
-
-		// For i3, optionally add to config for persistence
-		if wm == "i3" {
-			configPath := filepath.Join(os.Getenv("HOME"), ".config", "i3", "config")
-			if _, err := os.Stat(configPath); err == nil {
-				// Append feh command to i3 config if not already present
-				configContent, err := os.ReadFile(configPath)
-				if err != nil {
-					logBuffer.WriteString(fmt.Sprintf("Warning: could not read i3 config: %v\n", err))
-				} else if !strings.Contains(string(configContent), fmt.Sprintf("feh --bg-scale %s", absPath)) {
-					f, err := os.OpenFile(configPath, os.O_APPEND|os.O_WRONLY, 0644)
-					if err != nil {
-						logBuffer.WriteString(fmt.Sprintf("Warning: could not append to i3 config: %v\n", err))
-					} else {
-						defer f.Close()
-						fehLine := fmt.Sprintf("\nexec --no-startup-id feh --bg-scale %s\n", absPath)
-						if _, err := f.WriteString(fehLine); err != nil {
-							logBuffer.WriteString(fmt.Sprintf("Warning: could not write to i3 config: %v\n", err))
-						} else {
-							logBuffer.WriteString("Added feh command to i3 config for persistence\n")
-						}
-					}
-				}
-			}
-		}
-	default:
-		logBuffer.WriteString("Error: unknown window manager. Supported: gnome, dwm, i3.\n")
+	if err := setter.SetWallpaper(absPath, mode); err != nil {
+		logBuffer.WriteString(fmt.Sprintf("Error: %v\n", err))
 		if err := writeLog(); err != nil {
 			log.Printf("Failed to write log: %v", err)
 		}
-		return fmt.Errorf("unknown window manager: %s", wm)
+		return fmt.Errorf("failed to set wallpaper with %s: %v", setter.Name(), err)
 	}
 
 	logBuffer.WriteString("Wallpaper Set\n")
@@ -195,212 +123,167 @@ func setWallpaper(wallpaperFileName string, picturesDir string) error {
 	return writeLog()
 }
 
-func downloadWallpaper(url string, picturesDir string) error {
-	// Create HTTP client with headers to mimic a browser
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to fetch wallpaper page: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to parse HTML: %v", err)
-	}
-
-	// Try multiple selectors for the image
-	var src string
-	var exists bool
-
-	// Primary selector (original)
-	src, exists = doc.Find(".scrollbox img").Attr("data-cfsrc")
-	if !exists {
-		// Fallback 1: Check #wallpaper img
-		src, exists = doc.Find("#wallpaper").Attr("src")
-	}
-	if !exists {
-		// Fallback 2: Check img#showcase-wallpaper
-		src, exists = doc.Find("img#showcase-wallpaper").Attr("src")
-	}
-	if !exists {
-		// Debug: Print all img tags to inspect
-		fmt.Println("No image source found. Dumping img tags:")
-		logBuffer.WriteString("No image source found. Dumping img tags:\n")
-		doc.Find("img").Each(func(i int, s *goquery.Selection) {
-			imgSrc, _ := s.Attr("src")
-			imgDataSrc, _ := s.Attr("data-cfsrc")
-			fmt.Printf("img %d: src=%s, data-cfsrc=%s\n", i, imgSrc, imgDataSrc)
-			logBuffer.WriteString(fmt.Sprintf("img %d: src=%s, data-cfsrc=%s\n", i, imgSrc, imgDataSrc))
-		})
-		return fmt.Errorf("wallpaper image source not found")
-	}
-
-	wallpaperID, exists := doc.Find(".scrollbox img").Attr("data-wallpaper-id")
-	if !exists {
-		wallpaperID = "unknown"
-	}
-
-	wallpaperFileName := fmt.Sprintf("%d-%s.png", time.Now().UnixMilli(), wallpaperID)
-	filePath := filepath.Join(picturesDir, wallpaperFileName)
-
-	// Download the image
-	imgReq, err := http.NewRequest("GET", src, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create image request: %v", err)
-	}
-	imgReq.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0")
-
-	imgResp, err := client.Do(imgReq)
-	if err != nil {
-		return fmt.Errorf("failed to download wallpaper: %v", err)
-	}
-	defer imgResp.Body.Close()
-
-	if imgResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected image download status code: %d", imgResp.StatusCode)
-	}
-
-	fmt.Println("Starting Download")
-	logBuffer.WriteString("Starting Download\n")
-
-	f, err := os.Create(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to create image file: %v", err)
+func preparePicturesDir(picturesDir string) error {
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create logs directory: %v", err)
 	}
-	defer f.Close()
-
-	if _, err := io.Copy(f, imgResp.Body); err != nil {
-		return fmt.Errorf("failed to save image: %v", err)
+	if err := os.MkdirAll(picturesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create pictures directory: %v", err)
 	}
-
-	fmt.Println("Download done")
-	logBuffer.WriteString("Download done\n")
-
-	return setWallpaper(wallpaperFileName, picturesDir)
+	return nil
 }
 
-func fetchRandomWallpaperURL() (string, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", "https://wallhaven.cc/random", nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
-	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/115.0")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch random page: %v", err)
-	}
-	defer resp.Body.Close()
+// openStore opens the wallpaper index at <picturesDir>/.gohaven.db.
+func openStore(picturesDir string) (*storage.Store, error) {
+	return storage.Open(filepath.Join(picturesDir, ".gohaven.db"))
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+// runWithLog runs fn and flushes the accumulated logBuffer to disk
+// regardless of outcome, mirroring the rest of gohaven's logging convention.
+func runWithLog(fn func() error) error {
+	runErr := fn()
+	if runErr != nil {
+		fmt.Println(runErr)
+		logBuffer.WriteString(fmt.Sprintf("%v\n", runErr))
 	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse random page HTML: %v", err)
+	if err := writeLog(); err != nil {
+		log.Printf("Failed to write log: %v", err)
 	}
-
-	src, exists := doc.Find(".thumb a").First().Attr("href")
-	if !exists {
-		return "", fmt.Errorf("wallpaper random URL not found")
-	}
-
-	return src, nil
+	return runErr
 }
 
-func main() {
-	fmt.Println("Wallhaven Download Started")
-
-	// Prompt for picture directory path
-	fmt.Print("Enter the directory path to store wallpapers (e.g., /home/user/Pictures): ")
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Scan()
-	picturesDir := strings.TrimSpace(scanner.Text())
-
-	if picturesDir == "" {
-		fmt.Println("No directory provided, using default: ./Pictures")
-		logBuffer.WriteString("No directory provided, using default: ./Pictures\n")
-		picturesDir = "./Pictures"
+func newRootCmd() *cobra.Command {
+	var picturesDir, apiKey, mode, scriptPath string
+
+	root := &cobra.Command{
+		Use:   "gohaven",
+		Short: "Fetch and set wallpapers from Wallhaven and other sources",
+	}
+	root.PersistentFlags().StringVarP(&picturesDir, "dir", "d", "./Pictures", "directory to store downloaded wallpapers in")
+	root.PersistentFlags().StringVar(&apiKey, "apikey", "", "API key for the selected source (Wallhaven API key or Unsplash Client-ID)")
+	root.PersistentFlags().StringVar(&mode, "mode", string(wmset.ModeFill), "how to fit the wallpaper: fill, scale, tile, center, stretch")
+	root.PersistentFlags().StringVarP(&scriptPath, "script", "t", "", "executable to run after the wallpaper is set, with its path as the first argument")
+
+	randomCmd := &cobra.Command{Use: "random", Short: "Download and set a random wallpaper from any source, optionally filtered"}
+	randomFilters := addFilterFlags(randomCmd)
+	randomSource := addSourceFlags(randomCmd)
+	pages := randomCmd.Flags().Int("pages", 3, "number of result pages to pick a random wallpaper from (wallhaven source only)")
+	randomCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runWithLog(func() error {
+			if err := preparePicturesDir(picturesDir); err != nil {
+				return err
+			}
+			provider, err := buildProvider(randomSource, apiKey, randomFilters)
+			if err != nil {
+				return err
+			}
+			if p, ok := provider.(*providerwallhaven.Provider); ok {
+				p.Pages = *pages
+			}
+			store, err := openStore(picturesDir)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			_, err = fetchSetAndHook(cmd.Context(), randomSource.source, provider, picturesDir, wmset.Mode(mode), scriptPath, store)
+			return err
+		})
 	}
 
-	// Create logs and pictures directories if they don't exist
-	if err := os.MkdirAll(logsDir, 0755); err != nil {
-		log.Fatalf("Failed to create logs directory: %v", err)
+	searchCmd := &cobra.Command{Use: "search <query>", Short: "Search Wallhaven and set the top result as the wallpaper", Args: cobra.ExactArgs(1)}
+	searchFilters := addFilterFlags(searchCmd)
+	searchCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runWithLog(func() error {
+			if err := preparePicturesDir(picturesDir); err != nil {
+				return err
+			}
+			client := wallhaven.NewClient(apiKey)
+			provider := providerwallhaven.New(client, searchFilters.toParams(args[0]))
+			provider.PickTop = true
+			store, err := openStore(picturesDir)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			_, err = fetchSetAndHook(cmd.Context(), "wallhaven", provider, picturesDir, wmset.Mode(mode), scriptPath, store)
+			return err
+		})
 	}
-	if err := os.MkdirAll(picturesDir, 0755); err != nil {
-		log.Fatalf("Failed to create pictures directory: %v", err)
+
+	topCmd := &cobra.Command{Use: "top", Short: "Set the current top-list wallpaper as the wallpaper"}
+	topFilters := addFilterFlags(topCmd)
+	topCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runWithLog(func() error {
+			if err := preparePicturesDir(picturesDir); err != nil {
+				return err
+			}
+			params := topFilters.toParams("")
+			params.Sorting = "toplist"
+			client := wallhaven.NewClient(apiKey)
+			provider := providerwallhaven.New(client, params)
+			provider.PickTop = true
+			store, err := openStore(picturesDir)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			_, err = fetchSetAndHook(cmd.Context(), "wallhaven", provider, picturesDir, wmset.Mode(mode), scriptPath, store)
+			return err
+		})
 	}
 
-	// Log the chosen directory
-	fmt.Printf("Using pictures directory: %s\n", picturesDir)
-	logBuffer.WriteString(fmt.Sprintf("Using pictures directory: %s\n", picturesDir))
+	daemonCmd := &cobra.Command{Use: "daemon", Short: "Rotate wallpapers from any source on an interval until stopped"}
+	daemonFilters := addFilterFlags(daemonCmd)
+	daemonSource := addSourceFlags(daemonCmd)
+	interval := daemonCmd.Flags().Duration("interval", time.Hour, "how often to rotate wallpapers, e.g. 30m, 1h, 24h")
+	favoritesDir := daemonCmd.Flags().String("favorites", "", "directory to copy favorited wallpapers into (default <dir>/favorites)")
+	daemonCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := preparePicturesDir(picturesDir); err != nil {
+			return err
+		}
+		if *favoritesDir == "" {
+			*favoritesDir = filepath.Join(picturesDir, "favorites")
+		}
 
-	for {
-		// Fetch random wallpaper URL
-		src, err := fetchRandomWallpaperURL()
+		provider, err := buildProvider(daemonSource, apiKey, daemonFilters)
 		if err != nil {
-			fmt.Printf("Error fetching random wallpaper URL: %v\n", err)
-			logBuffer.WriteString(fmt.Sprintf("Error fetching random wallpaper URL: %v\n", err))
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
-			time.Sleep(2 * time.Second) // Wait before retrying
-			continue
+			return err
 		}
 
-		fmt.Println(src)
-		logBuffer.WriteString(fmt.Sprintf("%s\n", src))
-
-		// Download and set wallpaper
-		if err := downloadWallpaper(src, picturesDir); err != nil {
-			fmt.Printf("Error downloading wallpaper: %v\n", err)
-			logBuffer.WriteString(fmt.Sprintf("Error downloading wallpaper: %v\n", err))
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
-			time.Sleep(2 * time.Second) // Wait before retrying
-			continue
+		store, err := openStore(picturesDir)
+		if err != nil {
+			return err
 		}
-
-		// Prompt user to keep or get next wallpaper
-		fmt.Print("Do you like this wallpaper? (y/n): ")
-		scanner.Scan()
-		response := strings.ToLower(strings.TrimSpace(scanner.Text()))
-
-		if response == "y" {
-			fmt.Println("Keeping this wallpaper. Exiting.")
-			logBuffer.WriteString("User chose to keep wallpaper. Exiting.\n")
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
-			break
-		} else {
-			fmt.Println("Fetching next wallpaper...")
-			logBuffer.WriteString("User chose next wallpaper.\n")
-			if err := writeLog(); err != nil {
-				log.Printf("Failed to write log: %v", err)
-			}
+		defer store.Close()
+
+		d, err := daemon.New(daemon.Config{
+			Interval:     *interval,
+			FavoritesDir: *favoritesDir,
+			Log: func(msg string) {
+				fmt.Println(msg)
+				logBuffer.WriteString(msg + "\n")
+				if err := writeLog(); err != nil {
+					log.Printf("Failed to write log: %v", err)
+				}
+			},
+			Rotate: func(ctx context.Context) (string, error) {
+				return fetchSetAndHook(ctx, daemonSource.source, provider, picturesDir, wmset.Mode(mode), scriptPath, store)
+			},
+		})
+		if err != nil {
+			return err
 		}
+		return d.Run(cmd.Context())
 	}
 
-	// Print current working directory
-	wd, err := os.Getwd()
-	if err != nil {
-		log.Printf("Failed to get working directory: %v", err)
+	historyCmd := newHistoryCmd(&picturesDir, &mode, &scriptPath)
+
+	root.AddCommand(randomCmd, searchCmd, topCmd, daemonCmd, historyCmd)
+	return root
+}
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
 	}
-	fmt.Println(wd)
 }
@@ -0,0 +1,76 @@
+// Package bing fetches Bing's daily homepage image as a providers.Provider.
+package bing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bk0nlyrica/gohaven/providers"
+)
+
+const archiveURL = "https://www.bing.com/HPImageArchive.aspx?format=js&idx=0&n=1"
+
+type image struct {
+	URL     string `json:"url"`
+	URLBase string `json:"urlbase"`
+	Title   string `json:"title"`
+}
+
+type archiveResponse struct {
+	Images []image `json:"images"`
+}
+
+// Provider fetches Bing's current daily image.
+type Provider struct {
+	HTTPClient *http.Client
+}
+
+// New returns a Provider using http.DefaultClient.
+func New() *Provider {
+	return &Provider{HTTPClient: http.DefaultClient}
+}
+
+func (p *Provider) NextWallpaper(ctx context.Context) (providers.WallpaperMeta, providers.Fetch, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("bing: failed to create request: %w", err)
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("bing: failed to fetch image archive: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var archive archiveResponse
+	if err := json.NewDecoder(resp.Body).Decode(&archive); err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("bing: failed to decode image archive: %w", err)
+	}
+	if len(archive.Images) == 0 {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("bing: image archive was empty")
+	}
+
+	img := archive.Images[0]
+	imageURL := "https://www.bing.com" + img.URL
+
+	meta := providers.WallpaperMeta{
+		ID:        img.URLBase,
+		SourceURL: imageURL,
+		Tags:      []string{img.Title},
+	}
+	fetch := func(ctx context.Context) (io.ReadCloser, error) {
+		imgResp, err := p.HTTPClient.Get(imageURL)
+		if err != nil {
+			return nil, fmt.Errorf("bing: failed to download %s: %w", imageURL, err)
+		}
+		if imgResp.StatusCode != http.StatusOK {
+			imgResp.Body.Close()
+			return nil, fmt.Errorf("bing: unexpected status code %d for %s", imgResp.StatusCode, imageURL)
+		}
+		return imgResp.Body, nil
+	}
+	return meta, fetch, nil
+}
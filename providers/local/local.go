@@ -0,0 +1,80 @@
+// Package local picks a random image file from a directory as a
+// providers.Provider, for users who keep their own wallpaper collection.
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bk0nlyrica/gohaven/providers"
+)
+
+var imageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+	".bmp":  true,
+}
+
+// Provider picks a random image file from Dir each time it's asked for a
+// wallpaper.
+type Provider struct {
+	Dir string
+}
+
+// New returns a Provider that picks random image files from dir.
+func New(dir string) *Provider {
+	return &Provider{Dir: dir}
+}
+
+func (p *Provider) NextWallpaper(ctx context.Context) (providers.WallpaperMeta, providers.Fetch, error) {
+	entries, err := os.ReadDir(p.Dir)
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("local: failed to read %s: %w", p.Dir, err)
+	}
+
+	var images []fs.DirEntry
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if imageExtensions[strings.ToLower(filepath.Ext(e.Name()))] {
+			images = append(images, e)
+		}
+	}
+	if len(images) == 0 {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("local: no image files found in %s", p.Dir)
+	}
+
+	entry := images[rand.Intn(len(images))]
+	path := filepath.Join(p.Dir, entry.Name())
+
+	info, err := entry.Info()
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("local: failed to stat %s: %w", path, err)
+	}
+
+	// ID folds in size and mtime, not just the filename, so overwriting a
+	// file in place (same name, new content) gets a fresh ID instead of
+	// hitting the dedup path and silently re-setting the old bytes.
+	meta := providers.WallpaperMeta{
+		ID:        fmt.Sprintf("%s:%d:%d", entry.Name(), info.Size(), info.ModTime().UnixNano()),
+		SourceURL: path,
+	}
+	fetch := func(ctx context.Context) (io.ReadCloser, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("local: failed to open %s: %w", path, err)
+		}
+		return f, nil
+	}
+	return meta, fetch, nil
+}
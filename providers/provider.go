@@ -0,0 +1,37 @@
+// Package providers defines the common interface gohaven's wallpaper
+// sources implement, so the rest of the program can download, set, log and
+// hook into any of them the same way.
+package providers
+
+import (
+	"context"
+	"io"
+)
+
+// WallpaperMeta describes a single wallpaper returned by a Provider,
+// independent of where it came from.
+type WallpaperMeta struct {
+	// ID identifies the wallpaper within its source, if the source has a
+	// stable one (empty otherwise).
+	ID string
+	// SourceURL is the URL of the image itself (a file path for local
+	// sources).
+	SourceURL string
+	// Resolution is "WIDTHxHEIGHT", if known.
+	Resolution string
+	Tags       []string
+	Colors     []string
+}
+
+// Fetch downloads the image bytes for a wallpaper previously picked by
+// Provider.NextWallpaper. The caller must close the returned reader.
+type Fetch func(ctx context.Context) (io.ReadCloser, error)
+
+// Provider fetches wallpapers from a single source.
+type Provider interface {
+	// NextWallpaper picks the next wallpaper and returns its metadata along
+	// with a Fetch that downloads its image bytes. Fetch is not called
+	// until the caller actually needs the bytes, so a caller that already
+	// has the wallpaper stored (e.g. by ID) can skip the transfer entirely.
+	NextWallpaper(ctx context.Context) (WallpaperMeta, Fetch, error)
+}
@@ -0,0 +1,105 @@
+// Package reddit fetches a random image post from a subreddit's top listing
+// as a providers.Provider.
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"github.com/bk0nlyrica/gohaven/providers"
+)
+
+type listingResponse struct {
+	Data struct {
+		Children []struct {
+			Data struct {
+				ID    string `json:"id"`
+				URL   string `json:"url"`
+				Title string `json:"title"`
+			} `json:"data"`
+		} `json:"children"`
+	} `json:"data"`
+}
+
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+
+// Provider fetches a random image post from Subreddit's top listing.
+type Provider struct {
+	Subreddit  string
+	HTTPClient *http.Client
+}
+
+// New returns a Provider over the given subreddit, e.g. "wallpaper".
+func New(subreddit string) *Provider {
+	return &Provider{Subreddit: subreddit, HTTPClient: http.DefaultClient}
+}
+
+func (p *Provider) NextWallpaper(ctx context.Context) (providers.WallpaperMeta, providers.Fetch, error) {
+	listingURL := fmt.Sprintf("https://www.reddit.com/r/%s/top.json", p.Subreddit)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listingURL, nil)
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("reddit: failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "gohaven/1.0")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("reddit: failed to fetch r/%s: %w", p.Subreddit, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("reddit: unexpected status code %d for r/%s", resp.StatusCode, p.Subreddit)
+	}
+
+	var listing listingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("reddit: failed to decode listing: %w", err)
+	}
+
+	var candidates []int
+	for i, child := range listing.Data.Children {
+		if isImageURL(child.Data.URL) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("reddit: no image posts found in r/%s", p.Subreddit)
+	}
+
+	post := listing.Data.Children[candidates[rand.Intn(len(candidates))]].Data
+
+	meta := providers.WallpaperMeta{
+		ID:        post.ID,
+		SourceURL: post.URL,
+		Tags:      []string{post.Title},
+	}
+	fetch := func(ctx context.Context) (io.ReadCloser, error) {
+		imgResp, err := p.HTTPClient.Get(post.URL)
+		if err != nil {
+			return nil, fmt.Errorf("reddit: failed to download %s: %w", post.URL, err)
+		}
+		if imgResp.StatusCode != http.StatusOK {
+			imgResp.Body.Close()
+			return nil, fmt.Errorf("reddit: unexpected status code %d for %s", imgResp.StatusCode, post.URL)
+		}
+		return imgResp.Body, nil
+	}
+	return meta, fetch, nil
+}
+
+func isImageURL(url string) bool {
+	lower := strings.ToLower(url)
+	for _, ext := range imageExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
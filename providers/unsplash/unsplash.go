@@ -0,0 +1,81 @@
+// Package unsplash fetches a random photo from Unsplash as a
+// providers.Provider.
+package unsplash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bk0nlyrica/gohaven/providers"
+)
+
+const randomURL = "https://api.unsplash.com/photos/random"
+
+type urls struct {
+	Full string `json:"full"`
+}
+
+type photo struct {
+	ID     string `json:"id"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Color  string `json:"color"`
+	URLs   urls   `json:"urls"`
+}
+
+// Provider fetches a random photo from Unsplash using APIKey as the
+// Client-ID.
+type Provider struct {
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// New returns a Provider authenticated with apiKey.
+func New(apiKey string) *Provider {
+	return &Provider{APIKey: apiKey, HTTPClient: http.DefaultClient}
+}
+
+func (p *Provider) NextWallpaper(ctx context.Context) (providers.WallpaperMeta, providers.Fetch, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, randomURL, nil)
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("unsplash: failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Client-ID %s", p.APIKey))
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("unsplash: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("unsplash: unexpected status code %d", resp.StatusCode)
+	}
+
+	var ph photo
+	if err := json.NewDecoder(resp.Body).Decode(&ph); err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("unsplash: failed to decode response: %w", err)
+	}
+
+	meta := providers.WallpaperMeta{
+		ID:         ph.ID,
+		SourceURL:  ph.URLs.Full,
+		Resolution: fmt.Sprintf("%dx%d", ph.Width, ph.Height),
+		Colors:     []string{ph.Color},
+	}
+	fetch := func(ctx context.Context) (io.ReadCloser, error) {
+		imgResp, err := p.HTTPClient.Get(ph.URLs.Full)
+		if err != nil {
+			return nil, fmt.Errorf("unsplash: failed to download %s: %w", ph.URLs.Full, err)
+		}
+		if imgResp.StatusCode != http.StatusOK {
+			imgResp.Body.Close()
+			return nil, fmt.Errorf("unsplash: unexpected status code %d for %s", imgResp.StatusCode, ph.URLs.Full)
+		}
+		return imgResp.Body, nil
+	}
+	return meta, fetch, nil
+}
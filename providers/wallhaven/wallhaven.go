@@ -0,0 +1,84 @@
+// Package wallhaven adapts the wallhaven API client into a providers.Provider.
+package wallhaven
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bk0nlyrica/gohaven/providers"
+	"github.com/bk0nlyrica/gohaven/wallhaven"
+)
+
+// Provider fetches a wallpaper matching Params from Wallhaven.
+type Provider struct {
+	Client *wallhaven.Client
+	Params wallhaven.SearchParams
+	// Pages is how many pages of results to pick a random wallpaper from.
+	// Defaults to 3 if zero. Ignored when PickTop is true.
+	Pages int
+	// PickTop makes NextWallpaper return the first result of Params instead
+	// of picking randomly across Pages pages, for callers that want a
+	// specific query's or sorting's top result (e.g. the search and top
+	// subcommands).
+	PickTop bool
+}
+
+// New returns a Provider backed by client, picking randomly among results
+// matching params.
+func New(client *wallhaven.Client, params wallhaven.SearchParams) *Provider {
+	return &Provider{Client: client, Params: params, Pages: 3}
+}
+
+func (p *Provider) NextWallpaper(ctx context.Context) (providers.WallpaperMeta, providers.Fetch, error) {
+	w, err := p.pick(ctx)
+	if err != nil {
+		return providers.WallpaperMeta{}, nil, fmt.Errorf("wallhaven: %w", err)
+	}
+
+	tags := make([]string, len(w.Tags))
+	for i, t := range w.Tags {
+		tags[i] = t.Name
+	}
+
+	meta := providers.WallpaperMeta{
+		ID:         w.ID,
+		SourceURL:  w.Path,
+		Resolution: w.Resolution,
+		Tags:       tags,
+		Colors:     w.Colors,
+	}
+	fetch := func(ctx context.Context) (io.ReadCloser, error) {
+		resp, err := http.Get(w.Path)
+		if err != nil {
+			return nil, fmt.Errorf("wallhaven: failed to download %s: %w", w.Path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("wallhaven: unexpected status code %d for %s", resp.StatusCode, w.Path)
+		}
+		return resp.Body, nil
+	}
+	return meta, fetch, nil
+}
+
+// pick returns the next wallpaper per PickTop/Pages.
+func (p *Provider) pick(ctx context.Context) (*wallhaven.Wallpaper, error) {
+	if p.PickTop {
+		result, err := p.Client.Search(ctx, p.Params)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Wallpapers) == 0 {
+			return nil, fmt.Errorf("no wallpapers matched the given filters")
+		}
+		return &result.Wallpapers[0], nil
+	}
+
+	pages := p.Pages
+	if pages < 1 {
+		pages = 3
+	}
+	return p.Client.Random(ctx, p.Params, pages)
+}
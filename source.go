@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bk0nlyrica/gohaven/providers"
+	"github.com/bk0nlyrica/gohaven/providers/bing"
+	"github.com/bk0nlyrica/gohaven/providers/local"
+	"github.com/bk0nlyrica/gohaven/providers/reddit"
+	"github.com/bk0nlyrica/gohaven/providers/unsplash"
+	providerwallhaven "github.com/bk0nlyrica/gohaven/providers/wallhaven"
+	"github.com/bk0nlyrica/gohaven/storage"
+	"github.com/bk0nlyrica/gohaven/wallhaven"
+	"github.com/bk0nlyrica/gohaven/wmset"
+)
+
+// sourceFlags holds the flags needed to build a Provider for sources other
+// than Wallhaven.
+type sourceFlags struct {
+	source    string
+	subreddit string
+	localDir  string
+}
+
+// addSourceFlags registers the --source flag and its source-specific
+// companions on cmd.
+func addSourceFlags(cmd *cobra.Command) *sourceFlags {
+	f := &sourceFlags{}
+	cmd.Flags().StringVar(&f.source, "source", "wallhaven", "wallpaper source: wallhaven, bing, unsplash, reddit, local")
+	cmd.Flags().StringVar(&f.subreddit, "subreddit", "wallpaper", "subreddit to pull from when --source=reddit")
+	cmd.Flags().StringVar(&f.localDir, "local-dir", "", "directory to pick wallpapers from when --source=local")
+	return f
+}
+
+// buildProvider returns the providers.Provider named by f.source. apiKey is
+// used as the Wallhaven API key or the Unsplash Client-ID, depending on the
+// source.
+func buildProvider(f *sourceFlags, apiKey string, filters *filterFlags) (providers.Provider, error) {
+	switch f.source {
+	case "wallhaven":
+		client := wallhaven.NewClient(apiKey)
+		return providerwallhaven.New(client, filters.toParams("")), nil
+	case "bing":
+		return bing.New(), nil
+	case "unsplash":
+		return unsplash.New(apiKey), nil
+	case "reddit":
+		return reddit.New(f.subreddit), nil
+	case "local":
+		if f.localDir == "" {
+			return nil, fmt.Errorf("--local-dir is required when --source=local")
+		}
+		return local.New(f.localDir), nil
+	default:
+		return nil, fmt.Errorf("unknown source %q", f.source)
+	}
+}
+
+// fetchSetAndHook picks the next wallpaper from provider, storing it
+// content-addressed under picturesDir (or reusing the existing copy if store
+// already has it indexed, without transferring the image again), sets it as
+// the desktop background using mode, and runs scriptPath (if any) against
+// the result. It returns the wallpaper's absolute path.
+func fetchSetAndHook(ctx context.Context, source string, provider providers.Provider, picturesDir string, mode wmset.Mode, scriptPath string, store *storage.Store) (string, error) {
+	meta, fetch, err := provider.NextWallpaper(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error fetching wallpaper: %v", err)
+	}
+
+	fmt.Println(meta.SourceURL)
+	logBuffer.WriteString(fmt.Sprintf("%s\n", meta.SourceURL))
+
+	now := time.Now()
+
+	if existing, err := store.Lookup(source, meta.ID); err != nil {
+		return "", fmt.Errorf("error checking wallpaper index: %v", err)
+	} else if existing != nil {
+		logBuffer.WriteString(fmt.Sprintf("Already have %s/%s, skipping download\n", source, meta.ID))
+		if err := store.MarkSet(source, meta.ID, now); err != nil {
+			return "", fmt.Errorf("error updating wallpaper index: %v", err)
+		}
+		return setAndHook(existing.Path, picturesDir, meta, mode, scriptPath)
+	}
+
+	body, err := fetch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error fetching wallpaper: %v", err)
+	}
+	defer body.Close()
+
+	relPath, _, _, err := storage.SaveContent(body, picturesDir, filepath.Ext(meta.SourceURL))
+	if err != nil {
+		return "", fmt.Errorf("error downloading wallpaper: %v", err)
+	}
+
+	if err := store.Save(storage.Record{
+		Source:       source,
+		ID:           meta.ID,
+		Path:         relPath,
+		SourceURL:    meta.SourceURL,
+		Resolution:   meta.Resolution,
+		Tags:         meta.Tags,
+		Colors:       meta.Colors,
+		DownloadedAt: now,
+		LastSetAt:    now,
+	}); err != nil {
+		return "", fmt.Errorf("error updating wallpaper index: %v", err)
+	}
+
+	return setAndHook(relPath, picturesDir, meta, mode, scriptPath)
+}
+
+// setAndHook sets relPath (relative to picturesDir) as the wallpaper and
+// runs scriptPath against it, returning the absolute path.
+func setAndHook(relPath, picturesDir string, meta providers.WallpaperMeta, mode wmset.Mode, scriptPath string) (string, error) {
+	if err := setWallpaper(relPath, picturesDir, mode); err != nil {
+		return "", err
+	}
+
+	absPath, err := filepath.Abs(filepath.Join(picturesDir, relPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %v", err)
+	}
+
+	if err := runScript(scriptPath, absPath, meta); err != nil {
+		return absPath, err
+	}
+
+	return absPath, nil
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// extensionsByContentType maps the sniffed MIME types gohaven actually
+// expects to see back to a file extension. http.DetectContentType never
+// returns a bare "image/jpg", so only the real-world forms are listed here.
+var extensionsByContentType = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/bmp":  ".bmp",
+}
+
+// SaveContent reads body fully, stores it at
+// <picturesDir>/<sha256[:2]>/<sha256><ext> and returns that path (relative to
+// picturesDir) along with the hex-encoded sha256 and sniffed content type.
+// If the content already exists on disk under its hash, the existing file is
+// reused and the new copy is discarded, which is how re-downloads of the same image
+// are deduplicated even when a provider can't supply a stable ID.
+func SaveContent(body io.Reader, picturesDir, fallbackExt string) (relPath string, sha256Hex string, contentType string, err error) {
+	tmp, err := os.CreateTemp(picturesDir, ".download-*")
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	defer tmp.Close()
+
+	sniff := make([]byte, 512)
+	n, _ := io.ReadFull(body, sniff)
+	sniff = sniff[:n]
+	contentType = http.DetectContentType(sniff)
+
+	hasher := sha256.New()
+	w := io.MultiWriter(hasher, tmp)
+	if _, err := w.Write(sniff); err != nil {
+		return "", "", "", fmt.Errorf("failed to write image: %w", err)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return "", "", "", fmt.Errorf("failed to write image: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", "", "", fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	sha256Hex = hex.EncodeToString(hasher.Sum(nil))
+	ext := extensionsByContentType[contentType]
+	if ext == "" {
+		ext = fallbackExt
+	}
+
+	relPath = filepath.Join(sha256Hex[:2], sha256Hex+ext)
+	destPath := filepath.Join(picturesDir, relPath)
+
+	if _, err := os.Stat(destPath); err == nil {
+		return relPath, sha256Hex, contentType, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return "", "", "", fmt.Errorf("failed to create content directory: %w", err)
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return "", "", "", fmt.Errorf("failed to store image: %w", err)
+	}
+
+	return relPath, sha256Hex, contentType, nil
+}
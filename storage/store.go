@@ -0,0 +1,176 @@
+// Package storage provides content-addressed wallpaper storage backed by a
+// SQLite index of metadata, so the same wallpaper is never downloaded twice
+// and its history can be listed, reset or replayed.
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS wallpapers (
+	source        TEXT NOT NULL,
+	id            TEXT NOT NULL,
+	path          TEXT NOT NULL,
+	source_url    TEXT NOT NULL,
+	resolution    TEXT NOT NULL DEFAULT '',
+	tags          TEXT NOT NULL DEFAULT '',
+	colors        TEXT NOT NULL DEFAULT '',
+	downloaded_at DATETIME NOT NULL,
+	last_set_at   DATETIME NOT NULL,
+	favorite      BOOLEAN NOT NULL DEFAULT 0,
+	PRIMARY KEY (source, id)
+);
+`
+
+// Record is one wallpaper's metadata, as stored in the index.
+type Record struct {
+	Source       string
+	ID           string
+	Path         string // relative to the pictures directory
+	SourceURL    string
+	Resolution   string
+	Tags         []string
+	Colors       []string
+	DownloadedAt time.Time
+	LastSetAt    time.Time
+	Favorite     bool
+}
+
+// Store is a handle on the SQLite wallpaper index. The zero value is not
+// usable; construct one with Open.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the index database at dbPath.
+func Open(dbPath string) (*Store, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to open %s: %w", dbPath, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("storage: failed to create schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Lookup returns the record for (source, id), or nil if none is indexed yet.
+// A record with an empty id never matches, since a provider without a stable
+// ID can't be deduplicated this way.
+func (s *Store) Lookup(source, id string) (*Record, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	row := s.db.QueryRow(
+		`SELECT source, id, path, source_url, resolution, tags, colors, downloaded_at, last_set_at, favorite
+		 FROM wallpapers WHERE source = ? AND id = ?`, source, id)
+
+	r, err := scanRecord(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: lookup failed: %w", err)
+	}
+	return r, nil
+}
+
+// Save upserts r into the index.
+func (s *Store) Save(r Record) error {
+	_, err := s.db.Exec(
+		`INSERT INTO wallpapers (source, id, path, source_url, resolution, tags, colors, downloaded_at, last_set_at, favorite)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (source, id) DO UPDATE SET
+			path = excluded.path, source_url = excluded.source_url, resolution = excluded.resolution,
+			tags = excluded.tags, colors = excluded.colors, last_set_at = excluded.last_set_at, favorite = excluded.favorite`,
+		r.Source, r.ID, r.Path, r.SourceURL, r.Resolution,
+		strings.Join(r.Tags, ","), strings.Join(r.Colors, ","),
+		r.DownloadedAt, r.LastSetAt, r.Favorite,
+	)
+	if err != nil {
+		return fmt.Errorf("storage: save failed: %w", err)
+	}
+	return nil
+}
+
+// MarkSet updates a record's last_set_at timestamp, for replays and
+// dedup re-sets that don't otherwise touch the row.
+func (s *Store) MarkSet(source, id string, t time.Time) error {
+	_, err := s.db.Exec(`UPDATE wallpapers SET last_set_at = ? WHERE source = ? AND id = ?`, t, source, id)
+	if err != nil {
+		return fmt.Errorf("storage: mark set failed: %w", err)
+	}
+	return nil
+}
+
+// MarkFavorite sets or clears a record's favorite flag.
+func (s *Store) MarkFavorite(source, id string, favorite bool) error {
+	_, err := s.db.Exec(`UPDATE wallpapers SET favorite = ? WHERE source = ? AND id = ?`, favorite, source, id)
+	if err != nil {
+		return fmt.Errorf("storage: mark favorite failed: %w", err)
+	}
+	return nil
+}
+
+// History returns up to limit records, most recently set first.
+func (s *Store) History(limit int) ([]Record, error) {
+	rows, err := s.db.Query(
+		`SELECT source, id, path, source_url, resolution, tags, colors, downloaded_at, last_set_at, favorite
+		 FROM wallpapers ORDER BY last_set_at DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("storage: history query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		r, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("storage: failed to scan history row: %w", err)
+		}
+		records = append(records, *r)
+	}
+	return records, rows.Err()
+}
+
+// Reset clears the index. Downloaded files on disk are left untouched.
+func (s *Store) Reset() error {
+	if _, err := s.db.Exec(`DELETE FROM wallpapers`); err != nil {
+		return fmt.Errorf("storage: reset failed: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRecord(row rowScanner) (*Record, error) {
+	var r Record
+	var tags, colors string
+	if err := row.Scan(&r.Source, &r.ID, &r.Path, &r.SourceURL, &r.Resolution, &tags, &colors,
+		&r.DownloadedAt, &r.LastSetAt, &r.Favorite); err != nil {
+		return nil, err
+	}
+	if tags != "" {
+		r.Tags = strings.Split(tags, ",")
+	}
+	if colors != "" {
+		r.Colors = strings.Split(colors, ",")
+	}
+	return &r, nil
+}
@@ -0,0 +1,82 @@
+// Package wallhaven is a thin client for the official Wallhaven JSON API
+// (https://wallhaven.cc/help/api), used in place of scraping wallhaven.cc's
+// HTML pages.
+package wallhaven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const baseURL = "https://wallhaven.cc/api/v1"
+
+// Client talks to the Wallhaven API. The zero value is not usable; construct
+// one with NewClient.
+type Client struct {
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client authenticated with apiKey. apiKey may be empty,
+// in which case NSFW results are not accessible.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// Search queries /api/v1/search with the given filters and returns the
+// matching page of wallpapers.
+func (c *Client) Search(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	var sr searchResponse
+	if err := c.get(ctx, baseURL+"/search", params.values(), &sr); err != nil {
+		return nil, fmt.Errorf("wallhaven: search failed: %w", err)
+	}
+	return &SearchResult{
+		Wallpapers:  sr.Data,
+		CurrentPage: sr.Meta.CurrentPage,
+		LastPage:    sr.Meta.LastPage,
+	}, nil
+}
+
+// Get fetches a single wallpaper by id via /api/v1/w/{id}.
+func (c *Client) Get(ctx context.Context, id string) (*Wallpaper, error) {
+	var wr wallpaperResponse
+	if err := c.get(ctx, baseURL+"/w/"+id, nil, &wr); err != nil {
+		return nil, fmt.Errorf("wallhaven: get %s failed: %w", id, err)
+	}
+	return &wr.Data, nil
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, query interface {
+	Encode() string
+}, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if query != nil {
+		req.URL.RawQuery = query.Encode()
+	}
+	if c.APIKey != "" {
+		req.Header.Set("X-API-Key", c.APIKey)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package wallhaven
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// SearchParams holds the filters accepted by /api/v1/search. Zero values are
+// omitted from the request so the API falls back to its own defaults.
+type SearchParams struct {
+	Query      string // free-text query, may use tag:/id:/type:/etc. modifiers
+	Categories string // 3-digit bitmask, e.g. "111" for general+anime+people
+	Purity     string // 3-digit bitmask, e.g. "100" for sfw only
+	Sorting    string // date_added, relevance, random, views, favorites, toplist
+	Order      string // desc, asc
+	Ratios     string // comma separated, e.g. "16x9,16x10"
+	AtLeast    string // minimum resolution, e.g. "2560x1440"
+	Resolution string // exact resolution(s), comma separated
+	Page       int
+	// Seed pins the ordering for sorting=random across pages: Wallhaven
+	// reshuffles the whole result set on every request unless the same
+	// seed is sent each time, so without it "page 2" isn't a continuation
+	// of "page 1".
+	Seed string
+}
+
+// values encodes p as the query string parameters expected by the API.
+func (p SearchParams) values() url.Values {
+	v := url.Values{}
+	if p.Query != "" {
+		v.Set("q", p.Query)
+	}
+	if p.Categories != "" {
+		v.Set("categories", p.Categories)
+	}
+	if p.Purity != "" {
+		v.Set("purity", p.Purity)
+	}
+	if p.Sorting != "" {
+		v.Set("sorting", p.Sorting)
+	}
+	if p.Order != "" {
+		v.Set("order", p.Order)
+	}
+	if p.Ratios != "" {
+		v.Set("ratios", p.Ratios)
+	}
+	if p.AtLeast != "" {
+		v.Set("atleast", p.AtLeast)
+	}
+	if p.Resolution != "" {
+		v.Set("resolutions", p.Resolution)
+	}
+	if p.Page > 0 {
+		v.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.Seed != "" {
+		v.Set("seed", p.Seed)
+	}
+	return v
+}
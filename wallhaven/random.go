@@ -0,0 +1,52 @@
+package wallhaven
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+const seedAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// randomSeed returns a 6-character seed suitable for SearchParams.Seed, the
+// length Wallhaven's own clients use.
+func randomSeed() string {
+	b := make([]byte, 6)
+	for i := range b {
+		b[i] = seedAlphabet[rand.Intn(len(seedAlphabet))]
+	}
+	return string(b)
+}
+
+// Random fetches up to pages pages of results matching params and returns one
+// wallpaper picked at random from the combined set. params.Sorting is forced
+// to "random" and a fresh params.Seed is generated so the pages fetched are a
+// stable slice of one randomized ordering instead of each request reshuffling
+// the whole result set.
+func (c *Client) Random(ctx context.Context, params SearchParams, pages int) (*Wallpaper, error) {
+	if pages < 1 {
+		pages = 1
+	}
+	params.Sorting = "random"
+	params.Seed = randomSeed()
+
+	var all []Wallpaper
+	for page := 1; page <= pages; page++ {
+		params.Page = page
+		result, err := c.Search(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.Wallpapers...)
+		if result.CurrentPage >= result.LastPage {
+			break
+		}
+	}
+
+	if len(all) == 0 {
+		return nil, fmt.Errorf("wallhaven: no results for query %q", params.Query)
+	}
+
+	w := all[rand.Intn(len(all))]
+	return &w, nil
+}
@@ -0,0 +1,50 @@
+package wallhaven
+
+// Wallpaper is a single result returned by the Wallhaven API, trimmed down to
+// the fields gohaven actually uses.
+type Wallpaper struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	Path       string   `json:"path"`
+	Resolution string   `json:"resolution"`
+	Ratio      string   `json:"ratio"`
+	Purity     string   `json:"purity"`
+	Category   string   `json:"category"`
+	FileType   string   `json:"file_type"`
+	FileSize   int      `json:"file_size"`
+	Colors     []string `json:"colors"`
+	Tags       []Tag    `json:"tags"`
+}
+
+// Tag is a single tag attached to a wallpaper.
+type Tag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// meta mirrors the "meta" object the API attaches to /search responses.
+type meta struct {
+	CurrentPage int `json:"current_page"`
+	LastPage    int `json:"last_page"`
+	PerPage     int `json:"per_page"`
+	Total       int `json:"total"`
+}
+
+// searchResponse is the raw shape of a /api/v1/search response.
+type searchResponse struct {
+	Data []Wallpaper `json:"data"`
+	Meta meta        `json:"meta"`
+}
+
+// wallpaperResponse is the raw shape of a /api/v1/w/{id} response.
+type wallpaperResponse struct {
+	Data Wallpaper `json:"data"`
+}
+
+// SearchResult is the page of wallpapers returned by Search, along with the
+// pagination info needed to fetch further pages.
+type SearchResult struct {
+	Wallpapers  []Wallpaper
+	CurrentPage int
+	LastPage    int
+}
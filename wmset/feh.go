@@ -0,0 +1,90 @@
+package wmset
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	Register(fehSetter{})
+}
+
+// fehSetter sets the wallpaper via feh, used for minimal window managers
+// like dwm and i3 that have no background-setting facility of their own.
+type fehSetter struct{}
+
+func (fehSetter) Name() string { return "feh" }
+
+func (fehSetter) Detect() bool {
+	if envContainsAny(os.Getenv("XDG_CURRENT_DESKTOP"), "dwm", "i3") || envContainsAny(os.Getenv("DESKTOP_SESSION"), "dwm", "i3") {
+		return true
+	}
+	for _, wm := range []string{"dwm", "i3"} {
+		output, err := exec.Command("pgrep", "-l", wm).Output()
+		if err == nil && strings.Contains(string(output), wm) {
+			return true
+		}
+	}
+	return false
+}
+
+func (fehSetter) SetWallpaper(path string, mode Mode) error {
+	if _, err := exec.LookPath("feh"); err != nil {
+		return fmt.Errorf("feh not found: %v", err)
+	}
+
+	if err := run("feh", fehFlag(mode), path); err != nil {
+		return fmt.Errorf("failed to set wallpaper with feh: %v", err)
+	}
+
+	// For i3, add to config for persistence across restarts.
+	if envContainsAny(os.Getenv("XDG_CURRENT_DESKTOP"), "i3") || envContainsAny(os.Getenv("DESKTOP_SESSION"), "i3") {
+		appendFehToI3Config(path, mode)
+	}
+
+	return nil
+}
+
+// fehFlag maps a gohaven Mode to a feh --bg-* flag.
+func fehFlag(mode Mode) string {
+	switch mode {
+	case ModeFill:
+		return "--bg-fill"
+	case ModeTile:
+		return "--bg-tile"
+	case ModeCenter:
+		return "--bg-center"
+	case ModeStretch:
+		return "--bg-max"
+	case ModeScale:
+		fallthrough
+	default:
+		return "--bg-scale"
+	}
+}
+
+// appendFehToI3Config appends a feh exec line to ~/.config/i3/config if one
+// isn't already present, so the wallpaper persists across i3 restarts.
+// Failures here are non-fatal since the wallpaper has already been set.
+func appendFehToI3Config(path string, mode Mode) {
+	configPath := filepath.Join(os.Getenv("HOME"), ".config", "i3", "config")
+	if _, err := os.Stat(configPath); err != nil {
+		return
+	}
+
+	fehLine := fmt.Sprintf("exec --no-startup-id feh %s %s", fehFlag(mode), path)
+	configContent, err := os.ReadFile(configPath)
+	if err != nil || strings.Contains(string(configContent), fehLine) {
+		return
+	}
+
+	f, err := os.OpenFile(configPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.WriteString("\n" + fehLine + "\n")
+}
@@ -0,0 +1,80 @@
+package wmset
+
+import (
+	"fmt"
+	"os"
+)
+
+// gsettingsSetter sets the wallpaper via gsettings, for the GNOME-family
+// desktops that expose a background schema this way.
+type gsettingsSetter struct {
+	name      string
+	keywords  []string
+	schema    string
+	uriKey    string
+	optionKey string
+}
+
+func init() {
+	Register(gsettingsSetter{
+		name:      "gnome",
+		keywords:  []string{"gnome"},
+		schema:    "org.gnome.desktop.background",
+		uriKey:    "picture-uri",
+		optionKey: "picture-options",
+	})
+	Register(gsettingsSetter{
+		name:      "cinnamon",
+		keywords:  []string{"cinnamon"},
+		schema:    "org.cinnamon.desktop.background",
+		uriKey:    "picture-uri",
+		optionKey: "picture-options",
+	})
+	Register(gsettingsSetter{
+		name:      "mate",
+		keywords:  []string{"mate"},
+		schema:    "org.mate.background",
+		uriKey:    "picture-filename",
+		optionKey: "picture-options",
+	})
+}
+
+func (g gsettingsSetter) Name() string { return g.name }
+
+func (g gsettingsSetter) Detect() bool {
+	return envContainsAny(os.Getenv("XDG_CURRENT_DESKTOP"), g.keywords...) ||
+		envContainsAny(os.Getenv("DESKTOP_SESSION"), g.keywords...)
+}
+
+func (g gsettingsSetter) SetWallpaper(path string, mode Mode) error {
+	uri := path
+	if g.uriKey == "picture-uri" {
+		uri = fmt.Sprintf("file://%s", path)
+	}
+	if err := run("gsettings", "set", g.schema, g.uriKey, uri); err != nil {
+		return fmt.Errorf("failed to set wallpaper: %v", err)
+	}
+	if err := run("gsettings", "set", g.schema, g.optionKey, gsettingsPictureOptions(mode)); err != nil {
+		return fmt.Errorf("failed to set picture options: %v", err)
+	}
+	return nil
+}
+
+// gsettingsPictureOptions maps a gohaven Mode to the picture-options enum
+// shared by the GNOME, Cinnamon and MATE background schemas.
+func gsettingsPictureOptions(mode Mode) string {
+	switch mode {
+	case ModeFill:
+		return "zoom"
+	case ModeTile:
+		return "wallpaper"
+	case ModeCenter:
+		return "centered"
+	case ModeStretch:
+		return "stretched"
+	case ModeScale:
+		fallthrough
+	default:
+		return "scaled"
+	}
+}
@@ -0,0 +1,53 @@
+package wmset
+
+import (
+	"fmt"
+	"os"
+)
+
+func init() {
+	Register(kdeSetter{})
+}
+
+// kdeSetter sets the wallpaper under KDE Plasma by asking plasmashell to
+// evaluate a desktop script, since Plasma has no direct CLI for this.
+type kdeSetter struct{}
+
+func (kdeSetter) Name() string { return "kde" }
+
+func (kdeSetter) Detect() bool {
+	return envContainsAny(os.Getenv("XDG_CURRENT_DESKTOP"), "kde", "plasma")
+}
+
+func (kdeSetter) SetWallpaper(path string, mode Mode) error {
+	script := fmt.Sprintf(`
+var allDesktops = desktops();
+for (i=0; i<allDesktops.length; i++) {
+	d = allDesktops[i];
+	d.wallpaperPlugin = "org.kde.image";
+	d.currentConfigGroup = Array("Wallpaper", "org.kde.image", "General");
+	d.writeConfig("Image", "file://%s");
+	d.writeConfig("FillMode", %d);
+}
+`, path, kdeFillMode(mode))
+
+	return run("qdbus", "org.kde.plasmashell", "/PlasmaShell", "org.kde.PlasmaShell.evaluateScript", script)
+}
+
+// kdeFillMode maps a gohaven Mode to Plasma's QML Image.fillMode enum.
+func kdeFillMode(mode Mode) int {
+	switch mode {
+	case ModeFill:
+		return 2 // PreserveAspectCrop
+	case ModeTile:
+		return 3 // Tile
+	case ModeCenter:
+		return 6 // Pad (centered, no scaling)
+	case ModeStretch:
+		return 0 // Stretch
+	case ModeScale:
+		fallthrough
+	default:
+		return 1 // PreserveAspectFit
+	}
+}
@@ -0,0 +1,24 @@
+//go:build darwin
+
+package wmset
+
+import "fmt"
+
+func init() {
+	Register(macosSetter{})
+}
+
+// macosSetter sets the wallpaper on macOS by asking System Events, via
+// osascript, to apply it to every desktop.
+type macosSetter struct{}
+
+func (macosSetter) Name() string { return "macos" }
+
+func (macosSetter) Detect() bool { return true }
+
+// SetWallpaper sets path as the wallpaper on every desktop. macOS always
+// fills the screen with the image, so mode is ignored.
+func (macosSetter) SetWallpaper(path string, mode Mode) error {
+	script := fmt.Sprintf(`tell application "System Events" to tell every desktop to set picture to "%s"`, path)
+	return run("osascript", "-e", script)
+}
@@ -0,0 +1,47 @@
+package wmset
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+func init() {
+	Register(pekwmSetter{})
+}
+
+// pekwmSetter sets the wallpaper under the Pekwm window manager via
+// pekwm_bg.
+type pekwmSetter struct{}
+
+func (pekwmSetter) Name() string { return "pekwm" }
+
+func (pekwmSetter) Detect() bool {
+	if envContainsAny(os.Getenv("XDG_CURRENT_DESKTOP"), "pekwm") || envContainsAny(os.Getenv("DESKTOP_SESSION"), "pekwm") {
+		return true
+	}
+	output, err := exec.Command("pgrep", "-l", "pekwm").Output()
+	return err == nil && strings.Contains(string(output), "pekwm")
+}
+
+func (pekwmSetter) SetWallpaper(path string, mode Mode) error {
+	return run("pekwm_bg", "-D", pekwmStyle(mode), path)
+}
+
+// pekwmStyle maps a gohaven Mode to a pekwm_bg -D style.
+func pekwmStyle(mode Mode) string {
+	switch mode {
+	case ModeFill:
+		return "Scaled"
+	case ModeTile:
+		return "Tiled"
+	case ModeCenter:
+		return "Centered"
+	case ModeStretch:
+		return "Stretched"
+	case ModeScale:
+		fallthrough
+	default:
+		return "Image"
+	}
+}
@@ -0,0 +1,34 @@
+package wmset
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// run executes name with args and returns an error including stderr if the
+// command fails.
+func run(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	stderr := new(strings.Builder)
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return fmt.Errorf("%s: %v: %s", name, err, strings.TrimSpace(stderr.String()))
+		}
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// envContainsAny reports whether value, lowercased, contains any of needles.
+func envContainsAny(value string, needles ...string) bool {
+	value = strings.ToLower(value)
+	for _, n := range needles {
+		if strings.Contains(value, n) {
+			return true
+		}
+	}
+	return false
+}
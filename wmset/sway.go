@@ -0,0 +1,42 @@
+package wmset
+
+import "os"
+
+func init() {
+	Register(swaySetter{})
+}
+
+// swaySetter sets the wallpaper under the Sway Wayland compositor via
+// swaymsg.
+type swaySetter struct{}
+
+func (swaySetter) Name() string { return "sway" }
+
+func (swaySetter) Detect() bool {
+	if os.Getenv("SWAYSOCK") != "" {
+		return true
+	}
+	return os.Getenv("WAYLAND_DISPLAY") != "" && envContainsAny(os.Getenv("XDG_CURRENT_DESKTOP"), "sway")
+}
+
+func (swaySetter) SetWallpaper(path string, mode Mode) error {
+	return run("swaymsg", "output", "*", "bg", path, swayMode(mode))
+}
+
+// swayMode maps a gohaven Mode to the fit keyword swaymsg expects.
+func swayMode(mode Mode) string {
+	switch mode {
+	case ModeFill:
+		return "fill"
+	case ModeTile:
+		return "tile"
+	case ModeCenter:
+		return "center"
+	case ModeStretch:
+		return "stretch"
+	case ModeScale:
+		fallthrough
+	default:
+		return "fit"
+	}
+}
@@ -0,0 +1,53 @@
+//go:build windows
+
+package wmset
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	spiSetDeskWallpaper = 0x0014
+	spifUpdateINIFile   = 0x01
+	spifSendChange      = 0x02
+)
+
+var (
+	user32                    = syscall.NewLazyDLL("user32.dll")
+	procSystemParametersInfoW = user32.NewProc("SystemParametersInfoW")
+)
+
+func init() {
+	Register(windowsSetter{})
+}
+
+// windowsSetter sets the wallpaper on Windows via the
+// SystemParametersInfoW API.
+type windowsSetter struct{}
+
+func (windowsSetter) Name() string { return "windows" }
+
+func (windowsSetter) Detect() bool { return true }
+
+// SetWallpaper sets path as the wallpaper. Windows only supports a handful
+// of fixed styles via the registry (not SystemParametersInfoW), so mode is
+// ignored and the image is shown in whatever style Windows is already set to.
+func (windowsSetter) SetWallpaper(path string, mode Mode) error {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return fmt.Errorf("failed to convert path: %v", err)
+	}
+
+	ret, _, err := procSystemParametersInfoW.Call(
+		uintptr(spiSetDeskWallpaper),
+		0,
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(spifUpdateINIFile|spifSendChange),
+	)
+	if ret == 0 {
+		return fmt.Errorf("SystemParametersInfoW failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Package wmset sets the desktop wallpaper on whichever window manager or
+// desktop environment gohaven is running under.
+//
+// Each backend is a Setter registered in Setters; Detect walks that slice in
+// order and returns the first one that claims to be running, mirroring the
+// way wallutils organizes its per-WM setters.
+package wmset
+
+// Mode is how the wallpaper image should be fit to the screen.
+type Mode string
+
+const (
+	ModeFill    Mode = "fill"
+	ModeScale   Mode = "scale"
+	ModeTile    Mode = "tile"
+	ModeCenter  Mode = "center"
+	ModeStretch Mode = "stretch"
+)
+
+// Setter sets the wallpaper for one window manager or desktop environment.
+type Setter interface {
+	// Name identifies the backend, e.g. "sway" or "kde".
+	Name() string
+	// Detect reports whether this backend appears to be the one currently
+	// running.
+	Detect() bool
+	// SetWallpaper sets path as the desktop background using the given mode.
+	SetWallpaper(path string, mode Mode) error
+}
+
+// Setters holds every registered backend, in detection priority order.
+var Setters []Setter
+
+// Register adds s to Setters. Backends call this from an init function so
+// that importing the wmset package (and its backend files) is enough to
+// make them available.
+func Register(s Setter) {
+	Setters = append(Setters, s)
+}
+
+// Detect returns the first registered Setter whose Detect method reports
+// true, or nil if none matched.
+func Detect() Setter {
+	for _, s := range Setters {
+		if s.Detect() {
+			return s
+		}
+	}
+	return nil
+}
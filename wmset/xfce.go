@@ -0,0 +1,43 @@
+package wmset
+
+import "os"
+
+func init() {
+	Register(xfceSetter{})
+}
+
+// xfceSetter sets the wallpaper on the XFCE desktop via xfconf-query.
+type xfceSetter struct{}
+
+const xfceImageProperty = "/backdrop/screen0/monitor0/workspace0/last-image"
+
+func (xfceSetter) Name() string { return "xfce" }
+
+func (xfceSetter) Detect() bool {
+	return envContainsAny(os.Getenv("XDG_CURRENT_DESKTOP"), "xfce")
+}
+
+func (xfceSetter) SetWallpaper(path string, mode Mode) error {
+	if err := run("xfconf-query", "-c", "xfce4-desktop", "-p", xfceImageProperty, "-s", path); err != nil {
+		return err
+	}
+	return run("xfconf-query", "-c", "xfce4-desktop", "-p", "/backdrop/screen0/monitor0/workspace0/image-style", "-s", xfceImageStyle(mode))
+}
+
+// xfceImageStyle maps a gohaven Mode to xfce4-desktop's image-style enum.
+func xfceImageStyle(mode Mode) string {
+	switch mode {
+	case ModeFill:
+		return "5" // Zoomed (crop to fill)
+	case ModeTile:
+		return "2" // Tiled
+	case ModeCenter:
+		return "1" // Centered
+	case ModeStretch:
+		return "3" // Stretched
+	case ModeScale:
+		fallthrough
+	default:
+		return "4" // Scaled (preserve aspect)
+	}
+}